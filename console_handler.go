@@ -19,6 +19,7 @@ import (
 	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -28,6 +29,7 @@ import (
 	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 
 	"golang.org/x/term"
@@ -42,6 +44,51 @@ type NewConsoleHandlerOpts struct {
 	RemoveTime   bool
 	RemoveLevel  bool
 	RemoveSource bool
+
+	// Pretty renders attributes as dimmed, colorized "key=value" pairs with indented
+	// sub-sections for [slog.Group] instead of a single trailing JSON blob, and highlights
+	// errors in red with their Unwrap chain on continuation lines. Meant for interactive use.
+	Pretty bool
+
+	// Vmodule is a comma-separated list of "pattern=level" rules that override Level for
+	// records whose source file matches pattern, e.g. "net/*=debug,storage/wiredtiger.go=debug-4".
+	// Levels are parsed by [slog.Level.UnmarshalText]; slog has no built-in "trace" level, so use
+	// the lower-than-debug numeric form (e.g. "debug-4") for that verbosity instead.
+	// Patterns are matched against the same "dir/file.go" form used by [consoleHandler.shortPath].
+	// See [consoleHandler.SetVmodule] for the matching rules.
+	Vmodule string
+}
+
+// vmoduleRule is a single compiled "pattern=level" rule set by Vmodule or [consoleHandler.SetVmodule].
+type vmoduleRule struct {
+	pattern string
+	level   slog.Level
+}
+
+// parseVmodule parses a comma-separated "pattern=level" spec into rules.
+func parseVmodule(spec string) ([]vmoduleRule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(spec, ",")
+	rules := make([]vmoduleRule, 0, len(parts))
+
+	for _, p := range parts {
+		pattern, levelS, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("flog: invalid vmodule rule %q", p)
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(levelS)); err != nil {
+			return nil, fmt.Errorf("flog: invalid vmodule rule %q: %w", p, err)
+		}
+
+		rules = append(rules, vmoduleRule{pattern: pattern, level: level})
+	}
+
+	return rules, nil
 }
 
 // consoleHandler is a [slog.Handler] that writes logs to the console.
@@ -58,18 +105,32 @@ type consoleHandler struct {
 
 	m   *sync.Mutex
 	out io.Writer
+
+	vmodM *sync.RWMutex
+	vmod  []vmoduleRule
+
+	// vmodErr holds the error from parsing opts.Vmodule at construction time, if any.
+	// It is surfaced lazily from Handle instead of failing [NewConsoleHandler], so callers
+	// that never set Vmodule are unaffected by a signature that could fail.
+	vmodErr error
 }
 
 // NewConsoleHandler creates a new console handler.
 //
 // If out is a valid tty, the consoleHandler will send colorized messages.
 // If NO_COLOR environment variable is set colorized messages are disabled.
+//
+// If opts.Vmodule is set but fails to parse, NewConsoleHandler doesn't fail: the error is
+// saved and returned by every subsequent call to Handle, the same way a misconfigured
+// handler would surface any other unrecoverable error. Call [consoleHandler.SetVmodule]
+// directly (it returns the parse error synchronously) to validate a Vmodule spec upfront.
 func NewConsoleHandler(out io.Writer, opts *NewConsoleHandlerOpts) *consoleHandler {
 	ch := &consoleHandler{
 		opts:      cmp.Or(opts, new(NewConsoleHandlerOpts)),
 		testAttrs: nil,
 		m:         new(sync.Mutex),
 		out:       out,
+		vmodM:     new(sync.RWMutex),
 	}
 
 	if os.Getenv("NO_COLOR") == "" {
@@ -79,26 +140,121 @@ func NewConsoleHandler(out io.Writer, opts *NewConsoleHandlerOpts) *consoleHandl
 		}
 	}
 
+	if ch.opts.Vmodule != "" {
+		ch.vmodErr = ch.SetVmodule(ch.opts.Vmodule)
+	}
+
 	return ch
 }
 
+// SetVmodule (re)configures per-source-file level overrides; see [NewConsoleHandlerOpts.Vmodule]
+// for the spec format. It may be called concurrently with [consoleHandler.Handle].
+func (ch *consoleHandler) SetVmodule(spec string) error {
+	rules, err := parseVmodule(spec)
+	if err != nil {
+		return err
+	}
+
+	ch.vmodM.Lock()
+	ch.vmod = rules
+	ch.vmodM.Unlock()
+
+	return nil
+}
+
+// vmoduleLevel returns the level of the most specific vmodule rule matching file, if any.
+// Specificity is approximated by pattern length, the same way glog/go-ethereum's vmodule does.
+func (ch *consoleHandler) vmoduleLevel(file string) (slog.Level, bool) {
+	if file == "" {
+		return 0, false
+	}
+
+	short := ch.shortPath(file)
+
+	ch.vmodM.RLock()
+	defer ch.vmodM.RUnlock()
+
+	var (
+		level   slog.Level
+		found   bool
+		bestLen int
+	)
+
+	for _, r := range ch.vmod {
+		ok, err := filepath.Match(r.pattern, short)
+		if err != nil || !ok {
+			continue
+		}
+
+		if !found || len(r.pattern) > bestLen {
+			level = r.level
+			found = true
+			bestLen = len(r.pattern)
+		}
+	}
+
+	return level, found
+}
+
 // Enabled implements [slog.Handler].
+//
+// Enabled doesn't see the record's PC, so it can't apply a vmodule rule precisely; it returns
+// true optimistically whenever some rule could raise the effective level for l, and Handle
+// makes the real, source-aware decision once the frame is known.
 func (ch *consoleHandler) Enabled(_ context.Context, l slog.Level) bool {
 	minLevel := slog.LevelInfo
 	if ch.opts.Level != nil {
 		minLevel = ch.opts.Level.Level()
 	}
 
-	return l >= minLevel
+	if l >= minLevel {
+		return true
+	}
+
+	ch.vmodM.RLock()
+	defer ch.vmodM.RUnlock()
+
+	for _, r := range ch.vmod {
+		if l >= r.level {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Handle implements [slog.Handler].
 func (ch *consoleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ch.vmodErr != nil {
+		return ch.vmodErr
+	}
+
+	f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+
+	minLevel := slog.LevelInfo
+	if ch.opts.Level != nil {
+		minLevel = ch.opts.Level.Level()
+	}
+
+	if l, ok := ch.vmoduleLevel(f.File); ok {
+		minLevel = l
+	}
+
+	if r.Level < minLevel {
+		return nil
+	}
+
 	var buf bytes.Buffer
 
 	if !ch.opts.RemoveTime && !r.Time.IsZero() {
 		t := r.Time.Format(timeLayout)
-		buf.WriteString(t)
+
+		if ch.opts.Pretty {
+			buf.WriteString(ch.dim(t))
+		} else {
+			buf.WriteString(t)
+		}
+
 		buf.WriteRune('\t')
 
 		if ch.testAttrs != nil {
@@ -116,7 +272,6 @@ func (ch *consoleHandler) Handle(ctx context.Context, r slog.Record) error {
 	}
 
 	if !ch.opts.RemoveSource {
-		f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
 		if f.File != "" {
 			s := ch.shortPath(f.File) + ":" + strconv.Itoa(f.Line)
 			buf.WriteString(s)
@@ -136,18 +291,32 @@ func (ch *consoleHandler) Handle(ctx context.Context, r slog.Record) error {
 		}
 	}
 
-	if m := attrs(r, ch.ga); len(m) > 0 {
-		buf.WriteRune('\t')
+	// Context attrs are merged in after the WithAttrs/WithGroup chain and before r's own
+	// attrs, so a WithGroup open at log time still applies to them (and a same-keyed context
+	// attr shadows one set by WithAttrs, while r's own attrs shadow both).
+	ga := ch.ga
+	if ctxAttrs := FromContext(ctx); len(ctxAttrs) > 0 {
+		ga = append(slices.Clone(ch.ga), groupOrAttrs{attrs: ctxAttrs})
+	}
 
-		var b bytes.Buffer
-		encoder := json.NewEncoder(&b)
-		encoder.SetEscapeHTML(false)
+	if m := attrs(r, ga); len(m) > 0 {
+		if ch.opts.Pretty {
+			buf.WriteRune('\n')
+			ch.writePrettyAttrs(&buf, m, 1)
+			buf.Truncate(buf.Len() - 1) // drop writePrettyAttrs' trailing newline; one is added below
+		} else {
+			buf.WriteRune('\t')
 
-		if err := encoder.Encode(m); err != nil {
-			return err
-		}
+			var b bytes.Buffer
+			encoder := json.NewEncoder(&b)
+			encoder.SetEscapeHTML(false)
+
+			if err := encoder.Encode(m); err != nil {
+				return err
+			}
 
-		buf.Write(bytes.TrimSuffix(b.Bytes(), []byte{'\n'}))
+			buf.Write(bytes.TrimSuffix(b.Bytes(), []byte{'\n'}))
+		}
 
 		if ch.testAttrs != nil {
 			maps.Copy(ch.testAttrs, m)
@@ -176,6 +345,9 @@ func (ch *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		out:       ch.out,
 		esc:       ch.esc,
 		testAttrs: ch.testAttrs,
+		vmodM:     ch.vmodM,
+		vmod:      ch.vmod,
+		vmodErr:   ch.vmodErr,
 	}
 }
 
@@ -192,6 +364,9 @@ func (ch *consoleHandler) WithGroup(name string) slog.Handler {
 		out:       ch.out,
 		esc:       ch.esc,
 		testAttrs: ch.testAttrs,
+		vmodM:     ch.vmodM,
+		vmod:      ch.vmod,
+		vmodErr:   ch.vmodErr,
 	}
 }
 
@@ -216,6 +391,72 @@ func (ch *consoleHandler) colorizedLevel(l slog.Level) string {
 	}
 }
 
+// writePrettyAttrs writes m to buf as dimmed, colorized "key=value" pairs, one per line and
+// indented by indent levels, rendering nested [slog.Group] maps as indented sub-sections and
+// error values in red with their Unwrap chain on continuation lines.
+func (ch *consoleHandler) writePrettyAttrs(buf *bytes.Buffer, m map[string]any, indent int) {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		buf.WriteString(strings.Repeat("  ", indent))
+		buf.WriteString(ch.colorizedKey(k))
+
+		if sub, ok := m[k].(map[string]any); ok {
+			buf.WriteString(":\n")
+			ch.writePrettyAttrs(buf, sub, indent+1)
+
+			continue
+		}
+
+		buf.WriteRune('=')
+
+		if err, ok := m[k].(error); ok {
+			buf.WriteString(ch.colorizedError(err))
+		} else {
+			buf.WriteString(ch.dim(fmt.Sprint(m[k])))
+		}
+
+		buf.WriteRune('\n')
+	}
+}
+
+// colorizedKey returns a colorized attribute key, or k unchanged if ch can't print colors.
+func (ch *consoleHandler) colorizedKey(k string) string {
+	if ch.esc == nil {
+		return k
+	}
+
+	return string(ch.esc.Cyan) + k + string(ch.esc.Reset)
+}
+
+// colorizedError renders err's message in red, followed by its Unwrap chain (if any) dimmed
+// on indented continuation lines.
+func (ch *consoleHandler) colorizedError(err error) string {
+	s := ch.red(err.Error())
+
+	for u := errors.Unwrap(err); u != nil; u = errors.Unwrap(u) {
+		s += "\n    ↳ " + ch.dim(u.Error())
+	}
+
+	return s
+}
+
+// dim returns s wrapped in the ANSI "faint" escape, or s unchanged if ch can't print colors.
+func (ch *consoleHandler) dim(s string) string {
+	if ch.esc == nil {
+		return s
+	}
+
+	return "\x1b[2m" + s + string(ch.esc.Reset)
+}
+
+// red returns s colorized red, or s unchanged if ch can't print colors.
+func (ch *consoleHandler) red(s string) string {
+	if ch.esc == nil {
+		return s
+	}
+
+	return string(ch.esc.Red) + s + string(ch.esc.Reset)
+}
+
 // shortPath returns shorter path for the given path.
 func (ch *consoleHandler) shortPath(path string) string {
 	if path == "" {
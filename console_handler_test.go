@@ -0,0 +1,99 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestConsoleHandlerVmoduleLevel(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		vmodule string
+		file    string
+		wantOK  bool
+		wantLvl slog.Level
+	}{
+		"NoRules": {
+			vmodule: "",
+			file:    "/src/net/dial.go",
+			wantOK:  false,
+		},
+		"WildcardMatch": {
+			vmodule: "net/*=debug",
+			file:    "/src/net/dial.go",
+			wantOK:  true,
+			wantLvl: slog.LevelDebug,
+		},
+		"NoMatchingRule": {
+			vmodule: "net/*=debug",
+			file:    "/src/storage/wiredtiger.go",
+			wantOK:  false,
+		},
+		"MostSpecificRuleWins": {
+			vmodule: "net/*=debug,net/dial.go=warn",
+			file:    "/src/net/dial.go",
+			wantOK:  true,
+			wantLvl: slog.LevelWarn,
+		},
+		"WildcardStillAppliesToOtherFiles": {
+			vmodule: "net/*=debug,net/dial.go=warn",
+			file:    "/src/net/listen.go",
+			wantOK:  true,
+			wantLvl: slog.LevelDebug,
+		},
+		"EmptyFile": {
+			vmodule: "net/*=debug",
+			file:    "",
+			wantOK:  false,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ch := NewConsoleHandler(new(bytes.Buffer), nil)
+
+			if err := ch.SetVmodule(tc.vmodule); err != nil {
+				t.Fatalf("SetVmodule(%q): %s", tc.vmodule, err)
+			}
+
+			lvl, ok := ch.vmoduleLevel(tc.file)
+			if ok != tc.wantOK {
+				t.Fatalf("vmoduleLevel(%q) ok = %v, want %v", tc.file, ok, tc.wantOK)
+			}
+
+			if ok && lvl != tc.wantLvl {
+				t.Fatalf("vmoduleLevel(%q) level = %s, want %s", tc.file, lvl, tc.wantLvl)
+			}
+		})
+	}
+}
+
+func TestConsoleHandlerSetVmoduleInvalid(t *testing.T) {
+	t.Parallel()
+
+	ch := NewConsoleHandler(new(bytes.Buffer), nil)
+
+	for _, spec := range []string{"net/*", "net/*=bogus"} {
+		if err := ch.SetVmodule(spec); err == nil {
+			t.Errorf("SetVmodule(%q): want error, got nil", spec)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// ctxKey is the type of the context key used by [NewContext] and [FromContext].
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying attrs, in addition to any attributes already
+// attached to ctx by a previous call to NewContext.
+//
+// [consoleHandler.Handle] merges these attributes into every record logged with the
+// returned context (or a context derived from it), letting request handlers attach
+// request_id, connection_id, etc. once and have every downstream [slog] call carry them.
+func NewContext(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, ctxKey{}, append(slices.Clone(FromContext(ctx)), attrs...))
+}
+
+// FromContext returns the attributes attached to ctx by [NewContext], or nil if there are none.
+func FromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(ctxKey{}).([]slog.Attr)
+	return attrs
+}
@@ -0,0 +1,271 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"cmp"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FileOutputOpts represents [NewFileOutput] options.
+type FileOutputOpts struct {
+	// MaxSizeMB is the size in megabytes a log file may reach before it is rotated.
+	// Zero disables size-based rotation.
+	MaxSizeMB int
+
+	// MaxAgeDays is both the time since the active log file was last opened (or reopened on
+	// SIGHUP) after which it is rotated, and the age at which rotated backups are pruned.
+	// Zero disables age-based rotation and pruning.
+	MaxAgeDays int
+
+	// MaxBackups is the number of rotated log files to retain. Zero disables count-based pruning.
+	MaxBackups int
+
+	// Compress gzips rotated log files.
+	Compress bool
+}
+
+// fileOutput is an [io.WriteCloser] that writes to a log file, rotating it by size and age,
+// and reopening it when the process receives SIGHUP so external tools like logrotate can
+// manage it.
+type fileOutput struct {
+	path string
+	opts *FileOutputOpts
+
+	m        *sync.Mutex
+	f        *os.File
+	size     int64
+	openedAt time.Time
+
+	// hkM serializes background compress/prune housekeeping across rotations, kept separate
+	// from m so a slow gzip never blocks Write.
+	hkM *sync.Mutex
+
+	sig  chan os.Signal
+	done chan struct{}
+}
+
+// NewFileOutput creates a new [io.WriteCloser] that writes to the file at path, rotating it
+// according to opts and reopening it on SIGHUP.
+//
+// The returned writer may be passed directly as the out argument to [NewConsoleHandler],
+// [NewJSONHandler], or [NewLogfmtHandler]; a single Write is atomic with respect to rotation
+// and reopening, the same way [consoleHandler.m] makes a single Write atomic.
+//
+// Close must be called to stop watching for SIGHUP and release the underlying file.
+func NewFileOutput(path string, opts *FileOutputOpts) (io.WriteCloser, error) {
+	fo := &fileOutput{
+		path: path,
+		opts: cmp.Or(opts, new(FileOutputOpts)),
+		m:    new(sync.Mutex),
+		hkM:  new(sync.Mutex),
+		sig:  make(chan os.Signal, 1),
+		done: make(chan struct{}),
+	}
+
+	if err := fo.open(); err != nil {
+		return nil, err
+	}
+
+	signal.Notify(fo.sig, syscall.SIGHUP)
+	go fo.watch()
+
+	return fo, nil
+}
+
+// open opens (or reopens) the log file for appending and records its current size.
+func (fo *fileOutput) open() error {
+	f, err := os.OpenFile(fo.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("flog.NewFileOutput: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("flog.NewFileOutput: %w", err)
+	}
+
+	fo.f = f
+	fo.size = info.Size()
+	fo.openedAt = time.Now()
+
+	return nil
+}
+
+// watch reopens the log file every time the process receives SIGHUP, until Close is called.
+func (fo *fileOutput) watch() {
+	for {
+		select {
+		case <-fo.sig:
+			fo.m.Lock()
+			fo.f.Close()
+			fo.open() //nolint:errcheck // best effort; a failed reopen surfaces on the next Write
+
+			fo.m.Unlock()
+		case <-fo.done:
+			return
+		}
+	}
+}
+
+// Write implements [io.Writer].
+func (fo *fileOutput) Write(p []byte) (int, error) {
+	fo.m.Lock()
+	defer fo.m.Unlock()
+
+	dueToSize := fo.opts.MaxSizeMB > 0 && fo.size+int64(len(p)) > int64(fo.opts.MaxSizeMB)*1024*1024
+	dueToAge := fo.opts.MaxAgeDays > 0 && time.Since(fo.openedAt) >= time.Duration(fo.opts.MaxAgeDays)*24*time.Hour
+
+	if dueToSize || dueToAge {
+		if err := fo.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := fo.f.Write(p)
+	fo.size += int64(n)
+
+	return n, err
+}
+
+// rotate closes the current log file, renames it with a timestamp suffix, and reopens the
+// file at path. Compression and pruning are handed off to a background goroutine so a slow
+// gzip doesn't stall the caller's Write.
+func (fo *fileOutput) rotate() error {
+	if err := fo.f.Close(); err != nil {
+		return fmt.Errorf("flog: rotate %s: %w", fo.path, err)
+	}
+
+	backup := fo.path + "." + time.Now().UTC().Format("20060102T150405.000Z")
+
+	if err := os.Rename(fo.path, backup); err != nil {
+		return fmt.Errorf("flog: rotate %s: %w", fo.path, err)
+	}
+
+	if err := fo.open(); err != nil {
+		return err
+	}
+
+	go fo.houseKeep(backup)
+
+	return nil
+}
+
+// houseKeep compresses backup (if enabled) and prunes old backups, off the write path.
+// Rotations can outpace a slow gzip, so hkM serializes houseKeep calls against each other.
+func (fo *fileOutput) houseKeep(backup string) {
+	fo.hkM.Lock()
+	defer fo.hkM.Unlock()
+
+	if fo.opts.Compress {
+		// On failure, the uncompressed backup is left in place; prune still considers it valid.
+		fo.compress(backup) //nolint:errcheck
+	}
+
+	fo.prune() //nolint:errcheck // best effort; a failed prune just leaves extra backups until the next rotation
+}
+
+// compress gzips backup in place, replacing it with backup+".gz".
+func (fo *fileOutput) compress(backup string) error {
+	src, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(backup + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+
+	if _, err := io.Copy(gz, src); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(backup)
+}
+
+// prune removes rotated backup files older than opts.MaxAgeDays, then any beyond opts.MaxBackups.
+func (fo *fileOutput) prune() error {
+	if fo.opts.MaxAgeDays <= 0 && fo.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(fo.path + ".*")
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(backups)
+
+	if fo.opts.MaxAgeDays > 0 {
+		cutoff := time.Now().UTC().Add(-time.Duration(fo.opts.MaxAgeDays) * 24 * time.Hour)
+
+		kept := backups[:0]
+
+		for _, b := range backups {
+			info, err := os.Stat(b)
+			if err != nil || info.ModTime().Before(cutoff) {
+				os.Remove(b)
+				continue
+			}
+
+			kept = append(kept, b)
+		}
+
+		backups = kept
+	}
+
+	if fo.opts.MaxBackups > 0 && len(backups) > fo.opts.MaxBackups {
+		for _, b := range backups[:len(backups)-fo.opts.MaxBackups] {
+			os.Remove(b)
+		}
+	}
+
+	return nil
+}
+
+// Close stops watching for SIGHUP and closes the underlying file.
+func (fo *fileOutput) Close() error {
+	signal.Stop(fo.sig)
+	close(fo.done)
+
+	fo.m.Lock()
+	defer fo.m.Unlock()
+
+	return fo.f.Close()
+}
+
+// check interfaces
+var (
+	_ io.WriteCloser = (*fileOutput)(nil)
+)
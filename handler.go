@@ -0,0 +1,63 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// NewHandler creates a new [slog.Handler] for the given format: "console", "json", or "logfmt".
+//
+// It allows applications to select the log format from a single CLI flag or configuration
+// value, instead of hand-rolling the [slog.Handler] construction for each format.
+func NewHandler(format string, out io.Writer, opts *NewConsoleHandlerOpts) (slog.Handler, error) {
+	switch format {
+	case "console":
+		return NewConsoleHandler(out, opts), nil
+	case "json":
+		return NewJSONHandler(out, opts), nil
+	case "logfmt":
+		return NewLogfmtHandler(out, opts), nil
+	default:
+		return nil, fmt.Errorf("flog.NewHandler: unknown format %q", format)
+	}
+}
+
+// removeAttrs returns a [slog.HandlerOptions.ReplaceAttr] function that drops the
+// time/level/source attributes disabled by opts, matching what [NewConsoleHandler] does.
+func removeAttrs(opts *NewConsoleHandlerOpts) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 {
+			switch a.Key {
+			case slog.TimeKey:
+				if opts.RemoveTime {
+					return slog.Attr{}
+				}
+			case slog.LevelKey:
+				if opts.RemoveLevel {
+					return slog.Attr{}
+				}
+			case slog.SourceKey:
+				if opts.RemoveSource {
+					return slog.Attr{}
+				}
+			}
+		}
+
+		return a
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"cmp"
+	"io"
+	"log/slog"
+)
+
+// NewJSONHandler creates a new [slog.JSONHandler] that follows the same
+// [NewConsoleHandlerOpts] conventions as [NewConsoleHandler], so applications can switch
+// between formats without changing how they configure levels and removed fields.
+// opts.Vmodule and opts.Pretty are not used: every record is filtered by opts.Level alone.
+func NewJSONHandler(out io.Writer, opts *NewConsoleHandlerOpts) slog.Handler {
+	opts = cmp.Or(opts, new(NewConsoleHandlerOpts))
+
+	return slog.NewJSONHandler(out, &slog.HandlerOptions{
+		Level:       opts.Level,
+		AddSource:   !opts.RemoveSource,
+		ReplaceAttr: removeAttrs(opts),
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// sampleLRUSize bounds the number of distinct (level, message, source) keys a
+// [samplingHandler] tracks at once, so a hot path with ever-changing messages can't grow
+// its counters without bound.
+const sampleLRUSize = 4096
+
+// SamplingOpts represents [NewSamplingHandler] options.
+type SamplingOpts struct {
+	// First is the number of records per key allowed through unconditionally in each Tick window.
+	// Values below 1 are clamped to 1 by [NewSamplingHandler], so a key can never be fully
+	// silenced for an entire window.
+	First int
+
+	// ThereafterEvery, once First is exceeded within a Tick window, lets through one record
+	// out of every ThereafterEvery and drops the rest. Zero or below drops everything past First.
+	ThereafterEvery int
+
+	// Tick is the window after which a key's counters reset and its record may pass again.
+	// Values below 1 are clamped to defaultSamplingTick by [NewSamplingHandler], so a
+	// misconfigured zero Tick can't turn this into a permanent, silent black hole.
+	Tick time.Duration
+}
+
+// defaultSamplingTick is the [SamplingOpts.Tick] used when the configured value isn't positive.
+const defaultSamplingTick = time.Second
+
+// sampleEntry tracks how many records matching a given key have been seen and dropped
+// within the current Tick window.
+type sampleEntry struct {
+	key         string
+	count       int
+	dropped     int
+	windowStart time.Time
+}
+
+// samplingHandler is a [slog.Handler] that wraps another handler and drops repetitive
+// records above a configured rate, the same way zap and zerolog's sampling cores do.
+type samplingHandler struct {
+	inner slog.Handler
+	opts  SamplingOpts
+
+	m        *sync.Mutex
+	counters map[string]*list.Element
+	order    *list.List
+}
+
+// NewSamplingHandler creates a new [slog.Handler] that passes the first opts.First records
+// per (level, message, source file:line) key through to inner in each opts.Tick window, then
+// lets through one in every opts.ThereafterEvery and drops the rest.
+//
+// opts.First below 1 and opts.Tick below 1 are clamped (to 1 and [defaultSamplingTick]
+// respectively) so a degenerate config can't silently black-hole every record for good; at
+// worst it only suppresses non-First records within a window.
+//
+// When a window ends, the first record of the next window carries a "dropped" attribute
+// reporting how many records were suppressed during the window that just closed.
+func NewSamplingHandler(inner slog.Handler, opts SamplingOpts) slog.Handler {
+	if opts.First < 1 {
+		opts.First = 1
+	}
+
+	if opts.Tick < 1 {
+		opts.Tick = defaultSamplingTick
+	}
+
+	return &samplingHandler{
+		inner:    inner,
+		opts:     opts,
+		m:        new(sync.Mutex),
+		counters: make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Enabled implements [slog.Handler].
+func (sh *samplingHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return sh.inner.Enabled(ctx, l)
+}
+
+// Handle implements [slog.Handler].
+func (sh *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	key := fmt.Sprintf("%d\t%s\t%s:%d", r.Level, r.Message, f.File, f.Line)
+
+	pass, dropped := sh.sample(key)
+	if !pass {
+		return nil
+	}
+
+	if dropped > 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int("dropped", dropped))
+	}
+
+	return sh.inner.Handle(ctx, r)
+}
+
+// sample records a hit for key and reports whether it should pass through, along with the
+// number of records dropped during the window that just closed, if any.
+func (sh *samplingHandler) sample(key string) (pass bool, dropped int) {
+	sh.m.Lock()
+	defer sh.m.Unlock()
+
+	e := sh.entry(key)
+
+	now := time.Now()
+	if now.Sub(e.windowStart) >= sh.opts.Tick {
+		dropped = e.dropped
+		e.count = 0
+		e.dropped = 0
+		e.windowStart = now
+	}
+
+	e.count++
+
+	switch {
+	case e.count <= sh.opts.First:
+		pass = true
+	case sh.opts.ThereafterEvery > 0 && (e.count-sh.opts.First)%sh.opts.ThereafterEvery == 0:
+		pass = true
+	default:
+		e.dropped++
+	}
+
+	return
+}
+
+// entry returns the counter for key, creating one (and evicting the least recently used
+// entry if the LRU is full) if it doesn't exist yet.
+func (sh *samplingHandler) entry(key string) *sampleEntry {
+	if el, ok := sh.counters[key]; ok {
+		sh.order.MoveToFront(el)
+		return el.Value.(*sampleEntry)
+	}
+
+	e := &sampleEntry{key: key, windowStart: time.Now()}
+	sh.counters[key] = sh.order.PushFront(e)
+
+	if sh.order.Len() > sampleLRUSize {
+		oldest := sh.order.Back()
+		sh.order.Remove(oldest)
+		delete(sh.counters, oldest.Value.(*sampleEntry).key)
+	}
+
+	return e
+}
+
+// WithAttrs implements [slog.Handler].
+func (sh *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{
+		inner:    sh.inner.WithAttrs(attrs),
+		opts:     sh.opts,
+		m:        sh.m,
+		counters: sh.counters,
+		order:    sh.order,
+	}
+}
+
+// WithGroup implements [slog.Handler].
+func (sh *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{
+		inner:    sh.inner.WithGroup(name),
+		opts:     sh.opts,
+		m:        sh.m,
+		counters: sh.counters,
+		order:    sh.order,
+	}
+}
+
+// check interfaces
+var (
+	_ slog.Handler = (*samplingHandler)(nil)
+)
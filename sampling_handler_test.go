@@ -0,0 +1,131 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flog
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSamplingHandlerSample(t *testing.T) {
+	t.Parallel()
+
+	newHandler := func(opts SamplingOpts) *samplingHandler {
+		h := NewSamplingHandler(slog.NewTextHandler(io.Discard, nil), opts)
+		return h.(*samplingHandler)
+	}
+
+	t.Run("FirstAndThereafter", func(t *testing.T) {
+		t.Parallel()
+
+		sh := newHandler(SamplingOpts{First: 2, ThereafterEvery: 3, Tick: time.Hour})
+
+		// calls 1-2 are within First, so they pass; 3-4 are dropped; 5 is the 3rd call past
+		// First (count 5, First 2, so (5-2)%3 == 0) and passes.
+		wantPass := []bool{true, true, false, false, true}
+
+		for i, want := range wantPass {
+			pass, dropped := sh.sample("k")
+			if pass != want {
+				t.Errorf("call %d: pass = %v, want %v", i+1, pass, want)
+			}
+
+			if dropped != 0 {
+				t.Errorf("call %d: dropped = %d, want 0 (window hasn't reset)", i+1, dropped)
+			}
+		}
+	})
+
+	t.Run("ThereafterEveryZeroDropsAllPastFirst", func(t *testing.T) {
+		t.Parallel()
+
+		sh := newHandler(SamplingOpts{First: 1, ThereafterEvery: 0, Tick: time.Hour})
+
+		if pass, _ := sh.sample("k"); !pass {
+			t.Fatal("first call should pass")
+		}
+
+		for i := 0; i < 5; i++ {
+			if pass, _ := sh.sample("k"); pass {
+				t.Errorf("call %d: expected drop with ThereafterEvery = 0", i+2)
+			}
+		}
+	})
+
+	t.Run("WindowResetReportsDroppedCount", func(t *testing.T) {
+		t.Parallel()
+
+		sh := newHandler(SamplingOpts{First: 1, ThereafterEvery: 0, Tick: time.Minute})
+
+		if pass, dropped := sh.sample("k"); !pass || dropped != 0 {
+			t.Fatalf("first call: pass = %v, dropped = %d, want true, 0", pass, dropped)
+		}
+
+		for i := 0; i < 3; i++ {
+			if pass, _ := sh.sample("k"); pass {
+				t.Fatalf("call %d should have been dropped", i+2)
+			}
+		}
+
+		// force the window to have elapsed without sleeping in the test.
+		el := sh.counters["k"]
+		el.Value.(*sampleEntry).windowStart = time.Now().Add(-2 * time.Minute)
+
+		pass, dropped := sh.sample("k")
+		if !pass {
+			t.Fatal("first call of a new window should pass")
+		}
+
+		if dropped != 3 {
+			t.Fatalf("dropped = %d, want 3", dropped)
+		}
+	})
+
+	t.Run("KeysAreIndependent", func(t *testing.T) {
+		t.Parallel()
+
+		sh := newHandler(SamplingOpts{First: 1, ThereafterEvery: 0, Tick: time.Hour})
+
+		if pass, _ := sh.sample("a"); !pass {
+			t.Fatal("first call for key a should pass")
+		}
+
+		if pass, _ := sh.sample("b"); !pass {
+			t.Fatal("first call for key b should pass, independently of key a")
+		}
+	})
+}
+
+func TestNewSamplingHandlerClampsDegenerateOpts(t *testing.T) {
+	t.Parallel()
+
+	h := NewSamplingHandler(slog.NewTextHandler(io.Discard, nil), SamplingOpts{First: 0, ThereafterEvery: 0, Tick: 0})
+	sh := h.(*samplingHandler)
+
+	if sh.opts.First < 1 {
+		t.Errorf("First = %d, want >= 1", sh.opts.First)
+	}
+
+	if sh.opts.Tick < 1 {
+		t.Errorf("Tick = %d, want >= 1", sh.opts.Tick)
+	}
+
+	// with First clamped to at least 1, a key can never be dropped entirely within one window.
+	if pass, _ := sh.sample("k"); !pass {
+		t.Error("first call after clamping should still pass")
+	}
+}